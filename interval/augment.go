@@ -0,0 +1,46 @@
+package interval
+
+import "github.com/slobdell/persistent-red-black/tree"
+
+// augNode mirrors the shape of the underlying tree.RedBlackTree at the
+// tree.NodeHandle it was built from, pairing it with maxHi. It exists
+// purely to carry that derived field; handle is always the authority on
+// color, item, and children.
+type augNode[K, V any] struct {
+	handle tree.NodeHandle[Interval[K, V]]
+	maxHi  K
+	left   *augNode[K, V]
+	right  *augNode[K, V]
+}
+
+// rebuildAug walks h, the root of the tree as it is now, against old, the
+// augmented shadow of the tree as it was before the change that produced h.
+// A subtree whose handle is pointer-identical to the one at the same
+// position in old is the very same node old was built from, so its maxHi
+// (and everything under it) is reused outright instead of re-walked.
+// Reuse is by position only: an unchanged subtree that a rebalance moved
+// to a different position (for example, the sibling of a rotated node) is
+// re-walked and recomputed in full.
+func rebuildAug[K, V any](compare tree.Compare[K], old *augNode[K, V], h tree.NodeHandle[Interval[K, V]]) *augNode[K, V] {
+	if h.IsNil() {
+		return nil
+	}
+	if old != nil && old.handle == h {
+		return old
+	}
+	var oldLeft, oldRight *augNode[K, V]
+	if old != nil {
+		oldLeft, oldRight = old.left, old.right
+	}
+	left := rebuildAug(compare, oldLeft, h.Left())
+	right := rebuildAug(compare, oldRight, h.Right())
+
+	maxHi := h.Item().Hi
+	if left != nil && compare(left.maxHi, maxHi) > 0 {
+		maxHi = left.maxHi
+	}
+	if right != nil && compare(right.maxHi, maxHi) > 0 {
+		maxHi = right.maxHi
+	}
+	return &augNode[K, V]{handle: h, maxHi: maxHi, left: left, right: right}
+}
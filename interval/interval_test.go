@@ -0,0 +1,172 @@
+package interval
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func compareIntervalTestInt(a, b int) int { return a - b }
+
+func intervalsOverlap(lo1, hi1, lo2, hi2 int) bool {
+	return lo1 < hi2 && lo2 < hi1
+}
+
+func TestOverlappingStabbingCovers(t *testing.T) {
+	it := New[int, string](compareIntervalTestInt)
+	it = it.Insert(1, 5, "a")
+	it = it.Insert(4, 8, "b")
+	it = it.Insert(10, 12, "c")
+
+	var overlaps []string
+	for cur := it.Overlapping(3, 6); cur.HasElem(); cur.Next() {
+		overlaps = append(overlaps, cur.Elem().Value)
+	}
+	sort.Strings(overlaps)
+	if want := []string{"a", "b"}; !equalStrings(overlaps, want) {
+		t.Fatalf("Overlapping(3,6)=%v want %v", overlaps, want)
+	}
+
+	var stabs []string
+	for cur := it.Stabbing(4); cur.HasElem(); cur.Next() {
+		stabs = append(stabs, cur.Elem().Value)
+	}
+	sort.Strings(stabs)
+	if want := []string{"a", "b"}; !equalStrings(stabs, want) {
+		t.Fatalf("Stabbing(4)=%v want %v", stabs, want)
+	}
+
+	if !it.Covers(2, 4) {
+		t.Fatalf("expected [1,5) to cover [2,4)")
+	}
+	it = it.Delete(1, 5)
+	if it.Covers(2, 4) {
+		t.Fatalf("expected no interval to cover [2,4) after deleting [1,5)")
+	}
+}
+
+func TestOverlappingAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	it := New[int, int](compareIntervalTestInt)
+	type iv struct{ lo, hi int }
+	var ivs []iv
+	seen := map[iv]bool{}
+	for i := 0; i < 300; i++ {
+		lo := rng.Intn(200)
+		hi := lo + 1 + rng.Intn(20)
+		it = it.Insert(lo, hi, i)
+		if !seen[iv{lo, hi}] {
+			seen[iv{lo, hi}] = true
+			ivs = append(ivs, iv{lo, hi})
+		}
+	}
+
+	for q := 0; q < 50; q++ {
+		qlo := rng.Intn(200)
+		qhi := qlo + 1 + rng.Intn(20)
+
+		var want []int
+		for _, v := range ivs {
+			if intervalsOverlap(v.lo, v.hi, qlo, qhi) {
+				want = append(want, v.lo)
+			}
+		}
+		sort.Ints(want)
+
+		var got []int
+		for cur := it.Overlapping(qlo, qhi); cur.HasElem(); cur.Next() {
+			got = append(got, cur.Elem().Lo)
+		}
+		sort.Ints(got)
+
+		if !equalIntervalInts(got, want) {
+			t.Fatalf("query [%d,%d): got %v want %v", qlo, qhi, got, want)
+		}
+	}
+}
+
+func TestStabbingAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	it := New[int, int](compareIntervalTestInt)
+	type iv struct{ lo, hi int }
+	var ivs []iv
+	seen := map[iv]bool{}
+	for i := 0; i < 300; i++ {
+		lo := rng.Intn(200)
+		hi := lo + 1 + rng.Intn(20)
+		it = it.Insert(lo, hi, i)
+		if !seen[iv{lo, hi}] {
+			seen[iv{lo, hi}] = true
+			ivs = append(ivs, iv{lo, hi})
+		}
+	}
+
+	for q := 0; q < 50; q++ {
+		point := rng.Intn(220)
+
+		var want []int
+		for _, v := range ivs {
+			if v.lo <= point && point < v.hi {
+				want = append(want, v.lo)
+			}
+		}
+		sort.Ints(want)
+
+		var got []int
+		for cur := it.Stabbing(point); cur.HasElem(); cur.Next() {
+			got = append(got, cur.Elem().Lo)
+		}
+		sort.Ints(got)
+
+		if !equalIntervalInts(got, want) {
+			t.Fatalf("point %d: got %v want %v", point, got, want)
+		}
+	}
+}
+
+func TestDeleteIsPersistent(t *testing.T) {
+	it1 := New[int, string](compareIntervalTestInt)
+	it1 = it1.Insert(1, 5, "a")
+	it1 = it1.Insert(4, 8, "b")
+
+	it2 := it1.Delete(4, 8)
+
+	for cur := it2.Overlapping(4, 8); cur.HasElem(); cur.Next() {
+		if cur.Elem().Lo == 4 {
+			t.Fatalf("deleted interval [4,8) still present after Delete")
+		}
+	}
+	found := false
+	for cur := it1.Overlapping(4, 8); cur.HasElem(); cur.Next() {
+		if cur.Elem().Lo == 4 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the original tree to still contain [4,8) after deleting from the derived tree")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIntervalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package interval
+
+// Iterator walks a sequence of Interval results. It mirrors tree.Iterator's
+// Elem/HasElem/Next shape, but drops Seek: the results of Overlapping and
+// Stabbing are a pruned search, not a single sorted walk, so repositioning
+// by key doesn't have a well-defined meaning here.
+type Iterator[T any] interface {
+	Elem() T
+	HasElem() bool
+	Next()
+}
+
+type sliceIterator[T any] struct {
+	items []T
+	pos   int
+}
+
+func (it *sliceIterator[T]) Elem() T {
+	if !it.HasElem() {
+		var zero T
+		return zero
+	}
+	return it.items[it.pos]
+}
+
+func (it *sliceIterator[T]) HasElem() bool {
+	return it.pos < len(it.items)
+}
+
+func (it *sliceIterator[T]) Next() {
+	if it.HasElem() {
+		it.pos++
+	}
+}
+
+// Overlapping returns every stored [lo, hi) that overlaps the query range
+// [qlo, qhi), pruning subtrees whose maxHi can't reach qlo.
+func (t *PersistentIntervalTree[K, V]) Overlapping(qlo, qhi K) Iterator[Interval[K, V]] {
+	var results []Interval[K, V]
+	var walk func(n *augNode[K, V])
+	walk = func(n *augNode[K, V]) {
+		if n == nil {
+			return
+		}
+		item := n.handle.Item()
+		if n.left != nil && t.compare(n.left.maxHi, qlo) > 0 {
+			walk(n.left)
+		}
+		if t.compare(item.Lo, qhi) < 0 && t.compare(qlo, item.Hi) < 0 {
+			results = append(results, item)
+		}
+		if t.compare(item.Lo, qhi) < 0 {
+			walk(n.right)
+		}
+	}
+	walk(t.aug)
+	return &sliceIterator[Interval[K, V]]{items: results}
+}
+
+// Stabbing returns every stored [lo, hi) that contains point.
+func (t *PersistentIntervalTree[K, V]) Stabbing(point K) Iterator[Interval[K, V]] {
+	var results []Interval[K, V]
+	var walk func(n *augNode[K, V])
+	walk = func(n *augNode[K, V]) {
+		if n == nil {
+			return
+		}
+		item := n.handle.Item()
+		if n.left != nil && t.compare(n.left.maxHi, point) > 0 {
+			walk(n.left)
+		}
+		if t.compare(item.Lo, point) <= 0 && t.compare(point, item.Hi) < 0 {
+			results = append(results, item)
+		}
+		if t.compare(item.Lo, point) <= 0 {
+			walk(n.right)
+		}
+	}
+	walk(t.aug)
+	return &sliceIterator[Interval[K, V]]{items: results}
+}
+
+// Covers reports whether some single stored interval fully contains
+// [lo, hi).
+func (t *PersistentIntervalTree[K, V]) Covers(lo, hi K) bool {
+	found := false
+	var walk func(n *augNode[K, V])
+	walk = func(n *augNode[K, V]) {
+		if n == nil || found {
+			return
+		}
+		if n.left != nil && t.compare(n.left.maxHi, lo) > 0 {
+			walk(n.left)
+		}
+		if found {
+			return
+		}
+		item := n.handle.Item()
+		if t.compare(item.Lo, lo) <= 0 && t.compare(hi, item.Hi) <= 0 {
+			found = true
+			return
+		}
+		if t.compare(item.Lo, hi) < 0 {
+			walk(n.right)
+		}
+	}
+	walk(t.aug)
+	return found
+}
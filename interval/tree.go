@@ -0,0 +1,72 @@
+// Package interval provides a persistent interval tree built on top of the
+// tree package's own RedBlackTree, the same way RedBlackMap wraps it for a
+// keyed map: entries are ordered (and balanced) by lo then hi, so every
+// Insert/Delete is really a tree.RedBlackTree.Upsert/Delete underneath and
+// gets that package's rotations, split/join, and persistence for free.
+//
+// On top of that, each node is conceptually augmented with maxHi, the
+// largest hi anywhere in its subtree, which Overlapping/Stabbing/Covers use
+// to prune the search. Since tree.RedBlackTree's nodes don't carry that
+// extra field, it's maintained as a parallel shadow (augNode, in
+// augment.go) that mirrors the real tree's shape and is rebuilt after every
+// change by walking the new root against the old one, reusing (instead of
+// recomputing) any subtree whose tree.NodeHandle is pointer-identical to
+// the one at the same position before.
+package interval
+
+import "github.com/slobdell/persistent-red-black/tree"
+
+// Interval is a single [Lo, Hi) range with its payload, as produced by
+// Overlapping and Stabbing.
+type Interval[K, V any] struct {
+	Lo    K
+	Hi    K
+	Value V
+}
+
+// PersistentIntervalTree is a persistent map from [lo, hi) ranges to values
+// of type V, ordered (and balanced) by lo then hi.
+type PersistentIntervalTree[K, V any] struct {
+	compare tree.Compare[K]
+	entries *tree.RedBlackTree[Interval[K, V]]
+	aug     *augNode[K, V]
+}
+
+func entryCompare[K, V any](compare tree.Compare[K]) tree.Compare[Interval[K, V]] {
+	return func(a, b Interval[K, V]) int {
+		if cmp := compare(a.Lo, b.Lo); cmp != 0 {
+			return cmp
+		}
+		return compare(a.Hi, b.Hi)
+	}
+}
+
+// New creates an empty PersistentIntervalTree ordered by compare.
+func New[K, V any](compare tree.Compare[K]) *PersistentIntervalTree[K, V] {
+	return &PersistentIntervalTree[K, V]{
+		compare: compare,
+		entries: tree.NewRedBlack[Interval[K, V]](entryCompare[K, V](compare)),
+	}
+}
+
+// Insert returns a new tree with [lo, hi) mapped to v, replacing any entry
+// that previously had the exact same [lo, hi).
+func (t *PersistentIntervalTree[K, V]) Insert(lo, hi K, v V) *PersistentIntervalTree[K, V] {
+	entries := t.entries.Upsert(Interval[K, V]{Lo: lo, Hi: hi, Value: v})
+	return &PersistentIntervalTree[K, V]{
+		compare: t.compare,
+		entries: entries,
+		aug:     rebuildAug(t.compare, t.aug, entries.Root()),
+	}
+}
+
+// Delete returns a new tree with the exact entry [lo, hi) removed, if
+// present.
+func (t *PersistentIntervalTree[K, V]) Delete(lo, hi K) *PersistentIntervalTree[K, V] {
+	entries := t.entries.Delete(Interval[K, V]{Lo: lo, Hi: hi})
+	return &PersistentIntervalTree[K, V]{
+		compare: t.compare,
+		entries: entries,
+		aug:     rebuildAug(t.compare, t.aug, entries.Root()),
+	}
+}
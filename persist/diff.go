@@ -0,0 +1,64 @@
+package persist
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/slobdell/persistent-red-black/tree"
+)
+
+// diff markers distinguish, at each position in new's shape, whether the
+// corresponding subtree is absent, identical to old's (and so already
+// known to anyone who has old), or a genuinely new node that follows.
+const (
+	diffNil       = byte(0)
+	diffUnchanged = byte(1)
+	diffNode      = byte(2)
+)
+
+// Diff walks old and new in lockstep and writes only the nodes reachable
+// from new that are not also reachable, via the same path, from old. A
+// subtree is pruned the moment a NodeHandle from new is pointer-identical
+// to the one at the mirrored position in old. Pruning is by position only:
+// an unchanged subtree that a rebalance moved to a different position in
+// new (for example, the sibling of a rotated node) is walked and written
+// out again in full, so the cost is not bounded by what changed.
+//
+// The output is a patch meant to be replayed against a receiver that
+// already has old; unlike Marshal, it is not a self-contained, content-
+// addressed snapshot.
+func Diff[T any](oldTree, newTree *tree.RedBlackTree[T], w io.Writer, encode func(T) []byte) error {
+	return writeDiffNode(oldTree.Root(), newTree.Root(), w, encode)
+}
+
+func writeDiffNode[T any](oldH, newH tree.NodeHandle[T], w io.Writer, encode func(T) []byte) error {
+	if newH.IsNil() {
+		_, err := w.Write([]byte{diffNil})
+		return err
+	}
+	if oldH == newH {
+		_, err := w.Write([]byte{diffUnchanged})
+		return err
+	}
+	if _, err := w.Write([]byte{diffNode}); err != nil {
+		return err
+	}
+	colorByte := byte(0)
+	if newH.IsRed() {
+		colorByte = 1
+	}
+	if _, err := w.Write([]byte{colorByte}); err != nil {
+		return err
+	}
+	itemBytes := encode(newH.Item())
+	if err := binary.Write(w, binary.BigEndian, uint32(len(itemBytes))); err != nil {
+		return err
+	}
+	if _, err := w.Write(itemBytes); err != nil {
+		return err
+	}
+	if err := writeDiffNode(oldH.Left(), newH.Left(), w, encode); err != nil {
+		return err
+	}
+	return writeDiffNode(oldH.Right(), newH.Right(), w, encode)
+}
@@ -0,0 +1,168 @@
+// Package persist serializes a RedBlackTree as a content-addressed stream
+// of nodes: every node is identified by a hash of its own contents and its
+// children's hashes, and a subtree that occurs more than once within the
+// written stream is only ever written once. Each Marshal call writes a
+// single tree in full, so nodes it shares with another version of the tree
+// are written again; Diff ships just the difference between two versions.
+package persist
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/slobdell/persistent-red-black/tree"
+)
+
+// nodeHash identifies a node by its color, its children's hashes, and its
+// own encoded item. An all-zero hash stands for an empty subtree, since a
+// real sha256 output colliding with it is astronomically unlikely.
+type nodeHash [32]byte
+
+func hashNode(red bool, left, right nodeHash, item []byte) nodeHash {
+	h := sha256.New()
+	colorByte := byte(0)
+	if red {
+		colorByte = 1
+	}
+	h.Write([]byte{colorByte})
+	h.Write(left[:])
+	h.Write(right[:])
+	h.Write(item)
+	var out nodeHash
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+type record struct {
+	hash  nodeHash
+	red   bool
+	left  nodeHash
+	right nodeHash
+	item  []byte
+}
+
+// collect walks n in post-order (children before parent) so that by the
+// time a node is written, both of its children's hashes are already known,
+// and records each node at most once, keyed by content hash.
+func collect[T any](n tree.NodeHandle[T], encode func(T) []byte, seen map[nodeHash]bool, order *[]record) nodeHash {
+	if n.IsNil() {
+		return nodeHash{}
+	}
+	leftHash := collect(n.Left(), encode, seen, order)
+	rightHash := collect(n.Right(), encode, seen, order)
+	itemBytes := encode(n.Item())
+	hash := hashNode(n.IsRed(), leftHash, rightHash, itemBytes)
+	if !seen[hash] {
+		seen[hash] = true
+		*order = append(*order, record{hash: hash, red: n.IsRed(), left: leftHash, right: rightHash, item: itemBytes})
+	}
+	return hash
+}
+
+// Marshal writes root as a content-addressed node stream: a count, that
+// many node records (structurally identical subtrees appear only once),
+// and finally the root's hash.
+func Marshal[T any](root *tree.RedBlackTree[T], w io.Writer, encode func(T) []byte) error {
+	var order []record
+	seen := map[nodeHash]bool{}
+	rootHash := collect(root.Root(), encode, seen, &order)
+
+	if err := binary.Write(w, binary.BigEndian, uint32(len(order))); err != nil {
+		return err
+	}
+	for _, rec := range order {
+		if err := writeRecord(w, rec); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(rootHash[:])
+	return err
+}
+
+func writeRecord(w io.Writer, rec record) error {
+	if _, err := w.Write(rec.hash[:]); err != nil {
+		return err
+	}
+	colorByte := byte(0)
+	if rec.red {
+		colorByte = 1
+	}
+	if _, err := w.Write([]byte{colorByte}); err != nil {
+		return err
+	}
+	if _, err := w.Write(rec.left[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(rec.right[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(rec.item))); err != nil {
+		return err
+	}
+	_, err := w.Write(rec.item)
+	return err
+}
+
+func readRecord(r io.Reader) (record, error) {
+	var rec record
+	if _, err := io.ReadFull(r, rec.hash[:]); err != nil {
+		return record{}, err
+	}
+	var colorByte [1]byte
+	if _, err := io.ReadFull(r, colorByte[:]); err != nil {
+		return record{}, err
+	}
+	rec.red = colorByte[0] == 1
+	if _, err := io.ReadFull(r, rec.left[:]); err != nil {
+		return record{}, err
+	}
+	if _, err := io.ReadFull(r, rec.right[:]); err != nil {
+		return record{}, err
+	}
+	var itemLen uint32
+	if err := binary.Read(r, binary.BigEndian, &itemLen); err != nil {
+		return record{}, err
+	}
+	rec.item = make([]byte, itemLen)
+	if _, err := io.ReadFull(r, rec.item); err != nil {
+		return record{}, err
+	}
+	return rec, nil
+}
+
+// Unmarshal rebuilds a tree from a stream written by Marshal, sharing a
+// single node for every repeated hash rather than allocating a copy per
+// occurrence. compare is required to produce a usable RedBlackTree, since
+// the wire format itself carries no ordering information.
+func Unmarshal[T any](r io.Reader, compare tree.Compare[T], decode func([]byte) T) (*tree.RedBlackTree[T], error) {
+	var count uint32
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, err
+	}
+	byHash := make(map[nodeHash]tree.NodeHandle[T], count)
+	for i := uint32(0); i < count; i++ {
+		rec, err := readRecord(r)
+		if err != nil {
+			return nil, err
+		}
+		left := byHash[rec.left]
+		right := byHash[rec.right]
+		handle := tree.NewNodeHandle(decode(rec.item), rec.red, left, right)
+		byHash[rec.hash] = handle
+	}
+
+	var rootHash nodeHash
+	if _, err := io.ReadFull(r, rootHash[:]); err != nil {
+		return nil, err
+	}
+	if rootHash == (nodeHash{}) {
+		return tree.NewRedBlack[T](compare), nil
+	}
+	root, ok := byHash[rootHash]
+	if !ok {
+		return nil, fmt.Errorf("persist: root hash %x not found in stream", rootHash)
+	}
+	return tree.FromRoot(root, compare), nil
+}
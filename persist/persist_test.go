@@ -0,0 +1,120 @@
+package persist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/slobdell/persistent-red-black/tree"
+)
+
+func comparePersistTestInt(a, b int) int { return a - b }
+
+func encodePersistTestInt(i int) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(i))
+	return buf
+}
+
+func decodePersistTestInt(b []byte) int {
+	return int(binary.BigEndian.Uint64(b))
+}
+
+func buildPersistTestTree(vals []int) *tree.RedBlackTree[int] {
+	rb := tree.NewRedBlack[int](comparePersistTestInt)
+	for _, v := range vals {
+		rb = rb.Upsert(v)
+	}
+	return rb
+}
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	rb := buildPersistTestTree([]int{5, 1, 9, 3, 7, 2, 8, 4, 6})
+
+	var buf bytes.Buffer
+	if err := Marshal(rb, &buf, encodePersistTestInt); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	restored, err := Unmarshal[int](&buf, comparePersistTestInt, decodePersistTestInt)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	var got []int
+	for it := restored.Iterator(); it.HasElem(); it.Next() {
+		got = append(got, it.Elem())
+	}
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if len(got) != len(want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v want %v", got, want)
+		}
+	}
+}
+
+func TestMarshalEmptyTree(t *testing.T) {
+	rb := tree.NewRedBlack[int](comparePersistTestInt)
+	var buf bytes.Buffer
+	if err := Marshal(rb, &buf, encodePersistTestInt); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	restored, err := Unmarshal[int](&buf, comparePersistTestInt, decodePersistTestInt)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if restored.Len() != 0 {
+		t.Fatalf("expected an empty restored tree, got Len()=%d", restored.Len())
+	}
+}
+
+func TestMarshalRecordCountAfterSingleInsert(t *testing.T) {
+	rb1 := buildPersistTestTree([]int{1, 2, 3, 4, 5, 6, 7})
+	rb2 := rb1.Upsert(100)
+
+	var buf1, buf2 bytes.Buffer
+	if err := Marshal(rb1, &buf1, encodePersistTestInt); err != nil {
+		t.Fatalf("Marshal rb1: %v", err)
+	}
+	if err := Marshal(rb2, &buf2, encodePersistTestInt); err != nil {
+		t.Fatalf("Marshal rb2: %v", err)
+	}
+
+	var count1, count2 uint32
+	binary.Read(bytes.NewReader(buf1.Bytes()[:4]), binary.BigEndian, &count1)
+	binary.Read(bytes.NewReader(buf2.Bytes()[:4]), binary.BigEndian, &count2)
+	if count2-count1 > 4 {
+		t.Fatalf("expected only a few new records from inserting into a shared tree, rb1=%d rb2=%d", count1, count2)
+	}
+}
+
+func TestDiffPrunesUnchangedSubtrees(t *testing.T) {
+	rb1 := buildPersistTestTree([]int{1, 2, 3, 4, 5, 6, 7})
+	rb2 := rb1.Upsert(100)
+
+	var patch bytes.Buffer
+	if err := Diff(rb1, rb2, &patch, encodePersistTestInt); err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+
+	var full bytes.Buffer
+	if err := Marshal(rb2, &full, encodePersistTestInt); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if patch.Len() >= full.Len() {
+		t.Fatalf("expected diff (%d bytes) to be smaller than a full snapshot (%d bytes)", patch.Len(), full.Len())
+	}
+}
+
+func TestDiffIdenticalTreesIsAllUnchanged(t *testing.T) {
+	rb := buildPersistTestTree([]int{1, 2, 3})
+	var patch bytes.Buffer
+	if err := Diff(rb, rb, &patch, encodePersistTestInt); err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if patch.Len() != 1 {
+		t.Fatalf("expected a single unchanged marker, got %d bytes", patch.Len())
+	}
+}
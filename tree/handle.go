@@ -0,0 +1,66 @@
+package tree
+
+// NodeHandle is a read-only, exported view onto a single node, for
+// packages (such as persist) that need to walk a tree's actual shape and
+// detect structural sharing rather than just its in-order sequence of
+// items. Two handles compare equal with == exactly when they refer to the
+// same underlying node, which is what lets a caller prune a subtree it has
+// already seen instead of re-walking it.
+type NodeHandle[T any] struct {
+	n *node[T]
+}
+
+// IsNil reports whether the handle refers to an empty subtree.
+func (h NodeHandle[T]) IsNil() bool {
+	return h.n == nil
+}
+
+// Item returns the item stored at this node. It panics if IsNil is true.
+func (h NodeHandle[T]) Item() T {
+	return h.n.item
+}
+
+// IsRed reports this node's color.
+func (h NodeHandle[T]) IsRed() bool {
+	return h.n != nil && h.n.isRed()
+}
+
+// Left returns a handle to this node's left child, which is IsNil if there
+// is none.
+func (h NodeHandle[T]) Left() NodeHandle[T] {
+	if h.n == nil {
+		return NodeHandle[T]{}
+	}
+	return NodeHandle[T]{n: h.n.left}
+}
+
+// Right returns a handle to this node's right child, which is IsNil if
+// there is none.
+func (h NodeHandle[T]) Right() NodeHandle[T] {
+	if h.n == nil {
+		return NodeHandle[T]{}
+	}
+	return NodeHandle[T]{n: h.n.right}
+}
+
+// Root returns a handle to the tree's root node.
+func (r *RedBlackTree[T]) Root() NodeHandle[T] {
+	return NodeHandle[T]{n: r.root}
+}
+
+// NewNodeHandle builds a standalone node from an item, color, and children,
+// for reconstructing a tree (e.g. from a serialized form) node by node.
+func NewNodeHandle[T any](item T, isRed bool, left, right NodeHandle[T]) NodeHandle[T] {
+	c := color(black)
+	if isRed {
+		c = red
+	}
+	return NodeHandle[T]{n: mkNode(item, c, left.n, right.n)}
+}
+
+// FromRoot builds a RedBlackTree around an already-assembled node, such as
+// one produced by repeated calls to NewNodeHandle. Callers are responsible
+// for root being a valid red-black tree under compare.
+func FromRoot[T any](root NodeHandle[T], compare Compare[T]) *RedBlackTree[T] {
+	return &RedBlackTree[T]{root: root.n, compare: compare}
+}
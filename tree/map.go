@@ -0,0 +1,178 @@
+package tree
+
+// Combine resolves a collision between two values that share the same key
+// during Union or Intersection. Callers can implement "left wins", "right
+// wins", or an actual merge of a and b.
+type Combine[V any] func(a, b V) V
+
+// Entry is a single key/value pair produced while iterating a RedBlackMap.
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+type mapEntry[K, V any] struct {
+	key   K
+	value V
+}
+
+// RedBlackMap is a persistent, ordered associative container keyed by K.
+// It is built on the same Okasaki-style balancing as RedBlackTree, but
+// stores key and value separately instead of conflating them into a
+// single T, so ordering and equality are always decided by the key alone.
+type RedBlackMap[K, V any] struct {
+	tree       *RedBlackTree[mapEntry[K, V]]
+	compareKey Compare[K]
+}
+
+func compareEntries[K, V any](compareKey Compare[K]) Compare[mapEntry[K, V]] {
+	return func(this, that mapEntry[K, V]) int {
+		return compareKey(this.key, that.key)
+	}
+}
+
+// NewRedBlackMap creates an empty RedBlackMap ordered by compareKey.
+func NewRedBlackMap[K, V any](compareKey Compare[K]) *RedBlackMap[K, V] {
+	return &RedBlackMap[K, V]{
+		tree:       NewRedBlack[mapEntry[K, V]](compareEntries[K, V](compareKey)),
+		compareKey: compareKey,
+	}
+}
+
+// Get returns the value stored for k, and whether k was present.
+func (m *RedBlackMap[K, V]) Get(k K) (V, bool) {
+	n := m.tree.root
+	for n != nil {
+		cmp := m.compareKey(k, n.item.key)
+		if cmp == 0 {
+			return n.item.value, true
+		}
+		if cmp < 0 {
+			n = n.left
+			continue
+		}
+		n = n.right
+	}
+	var zero V
+	return zero, false
+}
+
+// Upsert returns a new RedBlackMap with k mapped to v, replacing any prior
+// value for k.
+func (m *RedBlackMap[K, V]) Upsert(k K, v V) *RedBlackMap[K, V] {
+	return &RedBlackMap[K, V]{
+		tree:       m.tree.Upsert(mapEntry[K, V]{key: k, value: v}),
+		compareKey: m.compareKey,
+	}
+}
+
+// Delete returns a new RedBlackMap with k removed, if it was present.
+func (m *RedBlackMap[K, V]) Delete(k K) *RedBlackMap[K, V] {
+	var zero V
+	return &RedBlackMap[K, V]{
+		tree:       m.tree.Delete(mapEntry[K, V]{key: k, value: zero}),
+		compareKey: m.compareKey,
+	}
+}
+
+// Len returns the number of entries in the map, in O(1) via the underlying
+// tree's size-augmented nodes.
+func (m *RedBlackMap[K, V]) Len() int {
+	return m.tree.Len()
+}
+
+// Union returns a new RedBlackMap containing every key from m and other.
+// When a key is present in both, combine(a, b) decides the resulting
+// value, where a comes from m and b comes from other. Like RedBlackTree's
+// Union, this is a split/join hedge over both trees at once rather than a
+// re-insertion of one map's entries into the other.
+func (m *RedBlackMap[K, V]) Union(other *RedBlackMap[K, V], combine Combine[V]) *RedBlackMap[K, V] {
+	c := compareEntries[K, V](m.compareKey)
+	return &RedBlackMap[K, V]{
+		tree:       &RedBlackTree[mapEntry[K, V]]{root: blacken(unionEntries(c, combine, m.tree.root, other.tree.root)), compare: c},
+		compareKey: m.compareKey,
+	}
+}
+
+// Intersection returns a new RedBlackMap containing only the keys present
+// in both m and other, with combine(a, b) deciding the resulting value.
+func (m *RedBlackMap[K, V]) Intersection(other *RedBlackMap[K, V], combine Combine[V]) *RedBlackMap[K, V] {
+	c := compareEntries[K, V](m.compareKey)
+	return &RedBlackMap[K, V]{
+		tree:       &RedBlackTree[mapEntry[K, V]]{root: blacken(intersectEntries(c, combine, m.tree.root, other.tree.root)), compare: c},
+		compareKey: m.compareKey,
+	}
+}
+
+// Subtract returns a new RedBlackMap containing the keys of m that are not
+// present in other. Key-only comparison means the plain tree-level subtract
+// already does the right thing here; no combine is needed.
+func (m *RedBlackMap[K, V]) Subtract(other *RedBlackMap[K, V]) *RedBlackMap[K, V] {
+	return &RedBlackMap[K, V]{
+		tree:       m.tree.Subtract(other.tree),
+		compareKey: m.compareKey,
+	}
+}
+
+// unionEntries is union (see splitjoin.go) with a combine-aware pivot: when
+// b's key is also found in a, the merged entry's value is combine(a's
+// value, b's value) instead of always taking b's.
+func unionEntries[K, V any](c Compare[mapEntry[K, V]], combine Combine[V], a, b *node[mapEntry[K, V]]) *node[mapEntry[K, V]] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	al, found, ar := split(a, c, b.item)
+	ul := unionEntries(c, combine, al, b.left)
+	ur := unionEntries(c, combine, ar, b.right)
+	pivot := b.item
+	if found != nil {
+		pivot = mapEntry[K, V]{key: b.item.key, value: combine(found.item.value, b.item.value)}
+	}
+	return join(ul, pivot, ur)
+}
+
+// intersectEntries is intersect (see splitjoin.go) with a combine-aware
+// pivot, merging the matched entry's values instead of keeping b's.
+func intersectEntries[K, V any](c Compare[mapEntry[K, V]], combine Combine[V], a, b *node[mapEntry[K, V]]) *node[mapEntry[K, V]] {
+	if a == nil || b == nil {
+		return nil
+	}
+	al, found, ar := split(a, c, b.item)
+	il := intersectEntries(c, combine, al, b.left)
+	ir := intersectEntries(c, combine, ar, b.right)
+	if found != nil {
+		pivot := mapEntry[K, V]{key: b.item.key, value: combine(found.item.value, b.item.value)}
+		return join(il, pivot, ir)
+	}
+	return join2(il, ir)
+}
+
+// Iterator walks the map's entries in ascending key order.
+func (m *RedBlackMap[K, V]) Iterator() Iterator[Entry[K, V]] {
+	return &mapIterator[K, V]{inner: m.tree.Iterator()}
+}
+
+type mapIterator[K, V any] struct {
+	inner Iterator[mapEntry[K, V]]
+}
+
+func (it *mapIterator[K, V]) Elem() Entry[K, V] {
+	e := it.inner.Elem()
+	return Entry[K, V]{Key: e.key, Value: e.value}
+}
+
+func (it *mapIterator[K, V]) HasElem() bool {
+	return it.inner.HasElem()
+}
+
+func (it *mapIterator[K, V]) Next() {
+	it.inner.Next()
+}
+
+// Seek repositions the iterator to the first entry whose key is >= item.Key.
+func (it *mapIterator[K, V]) Seek(item Entry[K, V]) {
+	it.inner.Seek(mapEntry[K, V]{key: item.Key, value: item.Value})
+}
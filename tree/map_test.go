@@ -0,0 +1,170 @@
+package tree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func compareMapTestInt(a, b int) int { return a - b }
+
+func TestRedBlackMapUpsertGetDelete(t *testing.T) {
+	rbm := NewRedBlackMap[int, string](compareMapTestInt)
+	rbm = rbm.Upsert(1, "one")
+	rbm = rbm.Upsert(2, "two")
+	rbm = rbm.Upsert(2, "TWO")
+
+	if v, ok := rbm.Get(2); !ok || v != "TWO" {
+		t.Fatalf("expected upsert to replace the value for key 2, got %q, %v", v, ok)
+	}
+	if v, ok := rbm.Get(1); !ok || v != "one" {
+		t.Fatalf("expected key 1 to be \"one\", got %q, %v", v, ok)
+	}
+	if rbm.Len() != 2 {
+		t.Fatalf("Len()=%d want 2", rbm.Len())
+	}
+
+	rbm = rbm.Delete(1)
+	if _, ok := rbm.Get(1); ok {
+		t.Fatalf("expected key 1 to be deleted")
+	}
+	if rbm.Len() != 1 {
+		t.Fatalf("Len()=%d want 1", rbm.Len())
+	}
+}
+
+func concatCombine(a, b string) string { return a + "|" + b }
+
+func TestRedBlackMapUnion(t *testing.T) {
+	m1 := NewRedBlackMap[int, string](compareMapTestInt)
+	m1 = m1.Upsert(1, "a1")
+	m1 = m1.Upsert(2, "a2")
+	m1 = m1.Upsert(3, "a3")
+
+	m2 := NewRedBlackMap[int, string](compareMapTestInt)
+	m2 = m2.Upsert(2, "b2")
+	m2 = m2.Upsert(3, "b3")
+	m2 = m2.Upsert(4, "b4")
+
+	union := m1.Union(m2, concatCombine)
+	want := map[int]string{1: "a1", 2: "a2|b2", 3: "a3|b3", 4: "b4"}
+	assertMapStringEquals(t, union, want)
+}
+
+func TestRedBlackMapIntersection(t *testing.T) {
+	m1 := NewRedBlackMap[int, string](compareMapTestInt)
+	m1 = m1.Upsert(1, "a1")
+	m1 = m1.Upsert(2, "a2")
+	m1 = m1.Upsert(3, "a3")
+
+	m2 := NewRedBlackMap[int, string](compareMapTestInt)
+	m2 = m2.Upsert(2, "b2")
+	m2 = m2.Upsert(3, "b3")
+	m2 = m2.Upsert(4, "b4")
+
+	inter := m1.Intersection(m2, concatCombine)
+	want := map[int]string{2: "a2|b2", 3: "a3|b3"}
+	assertMapStringEquals(t, inter, want)
+}
+
+func TestRedBlackMapSubtract(t *testing.T) {
+	m1 := NewRedBlackMap[int, string](compareMapTestInt)
+	m1 = m1.Upsert(1, "a1")
+	m1 = m1.Upsert(2, "a2")
+	m1 = m1.Upsert(3, "a3")
+
+	m2 := NewRedBlackMap[int, string](compareMapTestInt)
+	m2 = m2.Upsert(2, "b2")
+	m2 = m2.Upsert(4, "b4")
+
+	sub := m1.Subtract(m2)
+	want := map[int]string{1: "a1", 3: "a3"}
+	assertMapStringEquals(t, sub, want)
+}
+
+func assertMapStringEquals(t *testing.T, m *RedBlackMap[int, string], want map[int]string) {
+	t.Helper()
+	if m.Len() != len(want) {
+		t.Fatalf("Len()=%d want %d", m.Len(), len(want))
+	}
+	for it := m.Iterator(); it.HasElem(); it.Next() {
+		e := it.Elem()
+		wv, ok := want[e.Key]
+		if !ok {
+			t.Fatalf("unexpected key %d in result", e.Key)
+		}
+		if wv != e.Value {
+			t.Fatalf("key %d: got %q want %q", e.Key, e.Value, wv)
+		}
+	}
+}
+
+func TestRedBlackMapUnionIntersectionAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+	m1 := NewRedBlackMap[int, string](compareMapTestInt)
+	m2 := NewRedBlackMap[int, string](compareMapTestInt)
+	want1 := map[int]string{}
+	want2 := map[int]string{}
+
+	for i := 0; i < 150; i++ {
+		k := rng.Intn(80)
+		v := "a" + string(rune('A'+i%26))
+		m1 = m1.Upsert(k, v)
+		want1[k] = v
+	}
+	for i := 0; i < 150; i++ {
+		k := rng.Intn(80)
+		v := "b" + string(rune('A'+i%26))
+		m2 = m2.Upsert(k, v)
+		want2[k] = v
+	}
+
+	union := m1.Union(m2, concatCombine)
+	wantUnion := map[int]string{}
+	for k, v := range want1 {
+		wantUnion[k] = v
+	}
+	for k, v := range want2 {
+		if existing, ok := wantUnion[k]; ok {
+			wantUnion[k] = concatCombine(existing, v)
+		} else {
+			wantUnion[k] = v
+		}
+	}
+	assertMapStringEquals(t, union, wantUnion)
+
+	inter := m1.Intersection(m2, concatCombine)
+	wantInter := map[int]string{}
+	for k, v := range want1 {
+		if v2, ok := want2[k]; ok {
+			wantInter[k] = concatCombine(v, v2)
+		}
+	}
+	assertMapStringEquals(t, inter, wantInter)
+}
+
+func TestRedBlackMapIterationOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	rbm := NewRedBlackMap[int, int](compareMapTestInt)
+	want := map[int]int{}
+	for i := 0; i < 100; i++ {
+		k := rng.Intn(50)
+		rbm = rbm.Upsert(k, i)
+		want[k] = i
+	}
+
+	var keys []int
+	for it := rbm.Iterator(); it.HasElem(); it.Next() {
+		e := it.Elem()
+		if want[e.Key] != e.Value {
+			t.Fatalf("key %d: got %d want %d", e.Key, e.Value, want[e.Key])
+		}
+		keys = append(keys, e.Key)
+	}
+	if !sort.IntsAreSorted(keys) {
+		t.Fatalf("iterator did not walk keys in ascending order: %v", keys)
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("iterated %d keys, want %d", len(keys), len(want))
+	}
+}
@@ -0,0 +1,34 @@
+package tree
+
+import "golang.org/x/exp/constraints"
+
+// Ordered is implemented by types that know how to compare themselves to
+// another value of the same type, following the same negative/zero/positive
+// contract as Compare. It lets NewOrdered wire up a RedBlackTree without requiring
+// callers to write their own comparator function.
+type Ordered[T any] interface {
+	Compare(other T) int
+}
+
+// NewOrdered creates an empty RedBlackTree for a type that implements
+// Ordered, deriving the comparator from T.Compare instead of requiring one
+// to be passed in explicitly.
+func NewOrdered[T Ordered[T]]() *RedBlackTree[T] {
+	return NewRedBlack[T](func(this, that T) int {
+		return this.Compare(that)
+	})
+}
+
+// NewNative creates an empty RedBlackTree for any of the built-in ordered
+// types (integers, floats, strings), comparing them with < directly.
+func NewNative[T constraints.Ordered]() *RedBlackTree[T] {
+	return NewRedBlack[T](func(this, that T) int {
+		if this < that {
+			return -1
+		}
+		if this > that {
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,61 @@
+package tree
+
+import "testing"
+
+type orderedTestInt int
+
+func (o orderedTestInt) Compare(other orderedTestInt) int {
+	return int(o) - int(other)
+}
+
+func TestNewOrdered(t *testing.T) {
+	rb := NewOrdered[orderedTestInt]()
+	for _, v := range []orderedTestInt{5, 3, 8, 1} {
+		rb = rb.Upsert(v)
+	}
+	var got []orderedTestInt
+	for it := rb.Iterator(); it.HasElem(); it.Next() {
+		got = append(got, it.Elem())
+	}
+	want := []orderedTestInt{1, 3, 5, 8}
+	if len(got) != len(want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v want %v", got, want)
+		}
+	}
+}
+
+func TestNewNative(t *testing.T) {
+	rb := NewNative[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		rb = rb.Upsert(v)
+	}
+	var got []int
+	for it := rb.Iterator(); it.HasElem(); it.Next() {
+		got = append(got, it.Elem())
+	}
+	if want := []int{1, 3, 5, 8}; !equalInts(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
+
+// TestUpsertNegativeComparison guards the cmp < 0 fix in upsert: before it,
+// a comparator returning anything other than exactly -1 for "less than"
+// (as the subtraction-based comparator below does) could send an item down
+// the wrong side of the tree.
+func TestUpsertNegativeComparison(t *testing.T) {
+	rb := NewRedBlack[int](func(a, b int) int { return a - b })
+	for _, v := range []int{50, 10, 90, 30, 70} {
+		rb = rb.Upsert(v)
+	}
+	var got []int
+	for it := rb.Iterator(); it.HasElem(); it.Next() {
+		got = append(got, it.Elem())
+	}
+	if want := []int{10, 30, 50, 70, 90}; !equalInts(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package tree
+
+// Len returns the number of items in the tree in O(1), backed by the size
+// field every node carries for its own subtree.
+func (r *RedBlackTree[T]) Len() int {
+	return nodeSize(r.root)
+}
+
+// At returns the i-th smallest item (0-indexed) in O(log n), or false if i
+// is out of range.
+func (r *RedBlackTree[T]) At(i int) (T, bool) {
+	if i < 0 || i >= nodeSize(r.root) {
+		var zero T
+		return zero, false
+	}
+	n := r.root
+	for {
+		leftSize := nodeSize(n.left)
+		if i < leftSize {
+			n = n.left
+			continue
+		}
+		if i == leftSize {
+			return n.item, true
+		}
+		i -= leftSize + 1
+		n = n.right
+	}
+}
+
+// Rank returns the number of items strictly less than item. If item is
+// present, this is also its 0-indexed position as returned by At.
+func (r *RedBlackTree[T]) Rank(item T) int {
+	n := r.root
+	rank := 0
+	for n != nil {
+		cmp := r.compare(item, n.item)
+		if cmp <= 0 {
+			n = n.left
+			continue
+		}
+		rank += nodeSize(n.left) + 1
+		n = n.right
+	}
+	return rank
+}
+
+// Min returns the smallest item in the tree, or false if it is empty.
+func (r *RedBlackTree[T]) Min() (T, bool) {
+	if r.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := r.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.item, true
+}
+
+// Max returns the largest item in the tree, or false if it is empty.
+func (r *RedBlackTree[T]) Max() (T, bool) {
+	if r.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := r.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.item, true
+}
+
+// Floor returns the largest item <= item, or false if none exists.
+func (r *RedBlackTree[T]) Floor(item T) (T, bool) {
+	n := r.root
+	var best *node[T]
+	for n != nil {
+		cmp := r.compare(item, n.item)
+		if cmp == 0 {
+			return n.item, true
+		}
+		if cmp < 0 {
+			n = n.left
+			continue
+		}
+		best = n
+		n = n.right
+	}
+	if best == nil {
+		var zero T
+		return zero, false
+	}
+	return best.item, true
+}
+
+// Ceiling returns the smallest item >= item, or false if none exists.
+func (r *RedBlackTree[T]) Ceiling(item T) (T, bool) {
+	n := r.root
+	var best *node[T]
+	for n != nil {
+		cmp := r.compare(item, n.item)
+		if cmp == 0 {
+			return n.item, true
+		}
+		if cmp > 0 {
+			n = n.right
+			continue
+		}
+		best = n
+		n = n.left
+	}
+	if best == nil {
+		var zero T
+		return zero, false
+	}
+	return best.item, true
+}
+
+// seekStack descends from root to the first node >= lo, returning that node
+// as current along with the stack of its unvisited right-spine ancestors,
+// in the same shape the in-order iterator walks.
+func seekStack[T any](root *node[T], c Compare[T], lo T) (current *node[T], stack []*node[T]) {
+	n := root
+	for n != nil {
+		if c(lo, n.item) <= 0 {
+			stack = append(stack, n)
+			n = n.left
+			continue
+		}
+		n = n.right
+	}
+	if len(stack) == 0 {
+		return nil, nil
+	}
+	current = stack[len(stack)-1]
+	stack = stack[:len(stack)-1]
+	return current, stack
+}
+
+// RangeIterator walks items in [lo, hi] in ascending order, descending
+// directly to lo in O(log n) instead of starting from the minimum.
+func (r *RedBlackTree[T]) RangeIterator(lo, hi T) Iterator[T] {
+	current, stack := seekStack(r.root, r.compare, lo)
+	h := hi
+	return &nodeIterator[T]{
+		root:        r.root,
+		compare:     r.compare,
+		current:     current,
+		unprocStack: stack,
+		hi:          &h,
+	}
+}
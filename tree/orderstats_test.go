@@ -0,0 +1,86 @@
+package tree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func compareOrderStatsTestInt(a, b int) int { return a - b }
+
+func TestOrderStatistics(t *testing.T) {
+	ints := []int{5, 1, 9, 3, 7, 2, 8, 4, 6}
+	rb := buildOrderStatsTree(ints)
+
+	if rb.Len() != len(ints) {
+		t.Fatalf("Len()=%d want %d", rb.Len(), len(ints))
+	}
+	if v, ok := rb.At(0); !ok || v != 1 {
+		t.Fatalf("At(0)=%v,%v want 1,true", v, ok)
+	}
+	if v, ok := rb.At(rb.Len() - 1); !ok || v != 9 {
+		t.Fatalf("At(last)=%v,%v want 9,true", v, ok)
+	}
+	if v, ok := rb.Min(); !ok || v != 1 {
+		t.Fatalf("Min()=%v,%v want 1,true", v, ok)
+	}
+	if v, ok := rb.Max(); !ok || v != 9 {
+		t.Fatalf("Max()=%v,%v want 9,true", v, ok)
+	}
+	if v, ok := rb.Floor(6); !ok || v != 6 {
+		t.Fatalf("Floor(6)=%v,%v want 6,true", v, ok)
+	}
+	if v, ok := rb.Floor(0); ok {
+		t.Fatalf("Floor(0)=%v,%v want _,false", v, ok)
+	}
+	if v, ok := rb.Ceiling(10); ok {
+		t.Fatalf("Ceiling(10)=%v,%v want _,false", v, ok)
+	}
+	if rb.Rank(6) != 5 {
+		t.Fatalf("Rank(6)=%d want 5", rb.Rank(6))
+	}
+
+	var inRange []int
+	for it := rb.RangeIterator(3, 7); it.HasElem(); it.Next() {
+		inRange = append(inRange, it.Elem())
+	}
+	if want := []int{3, 4, 5, 6, 7}; !equalInts(inRange, want) {
+		t.Fatalf("RangeIterator(3,7)=%v want %v", inRange, want)
+	}
+}
+
+func buildOrderStatsTree(ints []int) *RedBlackTree[int] {
+	rb := NewRedBlack[int](compareOrderStatsTestInt)
+	for _, v := range ints {
+		rb = rb.Upsert(v)
+	}
+	return rb
+}
+
+func TestAtAndRankAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	seen := map[int]bool{}
+	var vals []int
+	rb := NewRedBlack[int](compareOrderStatsTestInt)
+	for i := 0; i < 200; i++ {
+		v := rng.Intn(500)
+		rb = rb.Upsert(v)
+		if !seen[v] {
+			seen[v] = true
+			vals = append(vals, v)
+		}
+	}
+	sort.Ints(vals)
+
+	if rb.Len() != len(vals) {
+		t.Fatalf("Len()=%d want %d", rb.Len(), len(vals))
+	}
+	for i, want := range vals {
+		if got, ok := rb.At(i); !ok || got != want {
+			t.Fatalf("At(%d)=%v,%v want %d,true", i, got, ok, want)
+		}
+		if rb.Rank(want) != i {
+			t.Fatalf("Rank(%d)=%d want %d", want, rb.Rank(want), i)
+		}
+	}
+}
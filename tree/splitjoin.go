@@ -0,0 +1,188 @@
+package tree
+
+// split, join, and the set operations built on top of them follow the
+// persistent "join-based" approach described by Adams and by Blelloch,
+// Ferizovic & Sun: instead of re-inserting one tree's elements into the
+// other one at a time, a tree is cut in two around a pivot (split) and two
+// trees are glued back together around a pivot (join), which lets Union,
+// Intersection, and Subtract divide-and-conquer over both trees at once.
+
+func isRedNode[T any](n *node[T]) bool {
+	return n != nil && n.isRed()
+}
+
+func blacken[T any](n *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	return n.copyWithColor(black)
+}
+
+// blackHeight counts the black nodes from n down to an empty child, along
+// any path; the red-black invariants guarantee every path has the same
+// count, so walking the left spine is enough. Empty nodes count as black.
+func blackHeight[T any](n *node[T]) int {
+	h := 1
+	for n != nil {
+		if !n.isRed() {
+			h++
+		}
+		n = n.left
+	}
+	return h
+}
+
+func rotateLeft[T any](n *node[T]) *node[T] {
+	r := n.right
+	return mkNode(r.item, r.c, mkNode(n.item, n.c, n.left, r.left), r.right)
+}
+
+func rotateRight[T any](n *node[T]) *node[T] {
+	l := n.left
+	return mkNode(l.item, l.c, l.left, mkNode(n.item, n.c, l.right, n.right))
+}
+
+// joinRightRB joins tl, pivot, and tr where blackHeight(tl) >= blackHeight(tr),
+// by walking down tl's right spine until a subtree of matching black height
+// is found, then rotating back up to repair any red-red violation it leaves
+// behind. The returned root may be red; join (the caller) decides whether it
+// needs blackening.
+func joinRightRB[T any](tl *node[T], pivot T, tr *node[T]) *node[T] {
+	if tl == nil {
+		return mkNode(pivot, red, nil, tr)
+	}
+	if !tl.isRed() && blackHeight(tl) == blackHeight(tr) {
+		return mkNode(pivot, red, tl, tr)
+	}
+	newRight := joinRightRB(tl.right, pivot, tr)
+	if !tl.isRed() && isRedNode(newRight) && isRedNode(newRight.right) {
+		fixed := mkNode(tl.item, tl.c, tl.left,
+			mkNode(newRight.item, newRight.c, newRight.left, newRight.right.copyWithColor(black)),
+		)
+		return rotateLeft(fixed)
+	}
+	return tl.copyWithRight(newRight)
+}
+
+// joinLeftRB is the mirror image of joinRightRB for blackHeight(tr) >= blackHeight(tl).
+func joinLeftRB[T any](tl *node[T], pivot T, tr *node[T]) *node[T] {
+	if tr == nil {
+		return mkNode(pivot, red, tl, nil)
+	}
+	if !tr.isRed() && blackHeight(tr) == blackHeight(tl) {
+		return mkNode(pivot, red, tl, tr)
+	}
+	newLeft := joinLeftRB(tl, pivot, tr.left)
+	if !tr.isRed() && isRedNode(newLeft) && isRedNode(newLeft.left) {
+		fixed := mkNode(tr.item, tr.c,
+			mkNode(newLeft.item, newLeft.c, newLeft.left.copyWithColor(black), newLeft.right), tr.right,
+		)
+		return rotateRight(fixed)
+	}
+	return tr.copyWithLeft(newLeft)
+}
+
+// join glues tl, pivot, and tr into a single valid red-black tree, where
+// every item in tl is less than pivot and every item in tr is greater.
+func join[T any](tl *node[T], pivot T, tr *node[T]) *node[T] {
+	lh, rh := blackHeight(tl), blackHeight(tr)
+	if lh > rh {
+		result := joinRightRB(tl, pivot, tr)
+		if isRedNode(result) && isRedNode(result.right) {
+			return result.copyWithColor(black)
+		}
+		return result
+	}
+	if rh > lh {
+		result := joinLeftRB(tl, pivot, tr)
+		if isRedNode(result) && isRedNode(result.left) {
+			return result.copyWithColor(black)
+		}
+		return result
+	}
+	if !isRedNode(tl) && !isRedNode(tr) {
+		return mkNode(pivot, red, tl, tr)
+	}
+	return mkNode(pivot, black, tl, tr)
+}
+
+// splitLast removes and returns the maximum item of n, along with the tree
+// that remains once it is gone.
+func splitLast[T any](n *node[T]) (*node[T], T) {
+	if n.right == nil {
+		return n.left, n.item
+	}
+	rest, last := splitLast(n.right)
+	return join(n.left, n.item, rest), last
+}
+
+// join2 concatenates left and right without a pivot of its own; every item
+// in left must be less than every item in right.
+func join2[T any](left, right *node[T]) *node[T] {
+	if left == nil {
+		return right
+	}
+	rest, pivot := splitLast(left)
+	return join(rest, pivot, right)
+}
+
+// split partitions n around key: everything less than key ends up in left,
+// everything greater in right, and found is the matching node (or nil).
+func split[T any](n *node[T], c Compare[T], key T) (left, found, right *node[T]) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	cmp := c(key, n.item)
+	if cmp == 0 {
+		return n.left, n, n.right
+	}
+	if cmp < 0 {
+		l, f, r := split(n.left, c, key)
+		return l, f, join(r, n.item, n.right)
+	}
+	l, f, r := split(n.right, c, key)
+	return join(n.left, n.item, l), f, r
+}
+
+// union implements the hedge algorithm: split a by b's root, recurse into
+// the matching halves, and join the pivot (from b, so b wins ties) back in.
+func union[T any](c Compare[T], a, b *node[T]) *node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	al, _, ar := split(a, c, b.item)
+	ul := union(c, al, b.left)
+	ur := union(c, ar, b.right)
+	return join(ul, b.item, ur)
+}
+
+func intersect[T any](c Compare[T], a, b *node[T]) *node[T] {
+	if a == nil || b == nil {
+		return nil
+	}
+	al, found, ar := split(a, c, b.item)
+	il := intersect(c, al, b.left)
+	ir := intersect(c, ar, b.right)
+	if found != nil {
+		return join(il, b.item, ir)
+	}
+	return join2(il, ir)
+}
+
+func subtract[T any](c Compare[T], a, b *node[T]) *node[T] {
+	if a == nil {
+		return nil
+	}
+	if b == nil {
+		return a
+	}
+	al, _, ar := split(a, c, b.item)
+	dl := subtract(c, al, b.left)
+	dr := subtract(c, ar, b.right)
+	// b.item belongs to the tree being subtracted out, so it is always
+	// dropped from the result regardless of whether a contained it.
+	return join2(dl, dr)
+}
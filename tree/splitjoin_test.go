@@ -0,0 +1,181 @@
+package tree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func compareSplitJoinTestInt(a, b int) int { return a - b }
+
+// checkInvariants walks r's nodes directly (this file is part of the tree
+// package, so it can reach root/left/right) and fails t if the red-black or
+// size invariants don't hold: no red node has a red child, every root-to-nil
+// path has the same number of black nodes, and every node's size equals
+// 1 + its children's sizes.
+func checkInvariants[T any](t *testing.T, r *RedBlackTree[T]) {
+	t.Helper()
+	if r.root != nil && r.root.isRed() {
+		t.Fatalf("root is red")
+	}
+	var walk func(n *node[T]) int
+	walk = func(n *node[T]) int {
+		if n == nil {
+			return 1
+		}
+		if n.isRed() {
+			if isRedNode(n.left) || isRedNode(n.right) {
+				t.Fatalf("red node has a red child")
+			}
+		}
+		if n.size != 1+nodeSize(n.left)+nodeSize(n.right) {
+			t.Fatalf("node size=%d want %d", n.size, 1+nodeSize(n.left)+nodeSize(n.right))
+		}
+		lh := walk(n.left)
+		rh := walk(n.right)
+		if lh != rh {
+			t.Fatalf("unequal black heights: left=%d right=%d", lh, rh)
+		}
+		if !n.isRed() {
+			return lh + 1
+		}
+		return lh
+	}
+	walk(r.root)
+}
+
+func buildTestTree(vals []int) *RedBlackTree[int] {
+	rb := NewRedBlack[int](compareSplitJoinTestInt)
+	for _, v := range vals {
+		rb = rb.Upsert(v)
+	}
+	return rb
+}
+
+func TestSplitJoinRoundTrip(t *testing.T) {
+	rb := buildTestTree([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	left, found, right := rb.Split(5)
+	if !found {
+		t.Fatalf("expected 5 to be found")
+	}
+	checkInvariants(t, left)
+	checkInvariants(t, right)
+
+	var gotLeft, gotRight []int
+	for it := left.Iterator(); it.HasElem(); it.Next() {
+		gotLeft = append(gotLeft, it.Elem())
+	}
+	for it := right.Iterator(); it.HasElem(); it.Next() {
+		gotRight = append(gotRight, it.Elem())
+	}
+	if want := []int{1, 2, 3, 4}; !equalInts(gotLeft, want) {
+		t.Fatalf("left=%v want %v", gotLeft, want)
+	}
+	if want := []int{6, 7, 8, 9}; !equalInts(gotRight, want) {
+		t.Fatalf("right=%v want %v", gotRight, want)
+	}
+
+	rejoined := Join[int](left, 5, right)
+	checkInvariants(t, rejoined)
+	var got []int
+	for it := rejoined.Iterator(); it.HasElem(); it.Next() {
+		got = append(got, it.Elem())
+	}
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !equalInts(got, want) {
+		t.Fatalf("rejoined=%v want %v", got, want)
+	}
+}
+
+func TestUnionIntersectionSubtractAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	a := map[int]bool{}
+	b := map[int]bool{}
+	ta := NewRedBlack[int](compareSplitJoinTestInt)
+	tb := NewRedBlack[int](compareSplitJoinTestInt)
+	for i := 0; i < 150; i++ {
+		v := rng.Intn(100)
+		a[v] = true
+		ta = ta.Upsert(v)
+	}
+	for i := 0; i < 150; i++ {
+		v := rng.Intn(100)
+		b[v] = true
+		tb = tb.Upsert(v)
+	}
+
+	union := ta.Union(tb)
+	checkInvariants(t, union)
+	wantUnion := unionSet(a, b)
+	assertTreeEquals(t, union, wantUnion)
+
+	inter := ta.Intersection(tb)
+	checkInvariants(t, inter)
+	wantInter := intersectSet(a, b)
+	assertTreeEquals(t, inter, wantInter)
+
+	sub := ta.Subtract(tb)
+	checkInvariants(t, sub)
+	wantSub := subtractSet(a, b)
+	assertTreeEquals(t, sub, wantSub)
+}
+
+func unionSet(a, b map[int]bool) map[int]bool {
+	out := map[int]bool{}
+	for k := range a {
+		out[k] = true
+	}
+	for k := range b {
+		out[k] = true
+	}
+	return out
+}
+
+func intersectSet(a, b map[int]bool) map[int]bool {
+	out := map[int]bool{}
+	for k := range a {
+		if b[k] {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+func subtractSet(a, b map[int]bool) map[int]bool {
+	out := map[int]bool{}
+	for k := range a {
+		if !b[k] {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+func assertTreeEquals(t *testing.T, rb *RedBlackTree[int], want map[int]bool) {
+	t.Helper()
+	var got []int
+	for it := rb.Iterator(); it.HasElem(); it.Next() {
+		got = append(got, it.Elem())
+	}
+	var wantSorted []int
+	for k := range want {
+		wantSorted = append(wantSorted, k)
+	}
+	sort.Ints(wantSorted)
+	if !equalInts(got, wantSorted) {
+		t.Fatalf("got %v want %v", got, wantSorted)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -7,6 +7,12 @@ const (
 	black = false
 )
 
+// Compare reports the ordering of this relative to that, following the
+// same contract as cmp.Compare in the standard library: negative when this
+// sorts before that, zero when they are equivalent, positive when this
+// sorts after that. Callers may return any negative or positive value, not
+// just -1/+1; every comparison in this package tests only the sign of cmp,
+// never equality against -1 or +1.
 type Compare[T any] func(this, that T) int
 
 type RedBlackTree[T any] struct {
@@ -27,15 +33,38 @@ type Iterator[T any] interface {
 	HasElem() bool
 	// Next moves the iterator to the next position.
 	Next()
+	// Seek repositions the iterator to the first element >= item in O(log n).
+	Seek(item T)
 }
 
 type node[T any] struct {
 	item  T
 	c     color
+	size  int
 	left  *node[T]
 	right *node[T]
 }
 
+// nodeSize returns n's subtree size, treating nil as an empty subtree.
+func nodeSize[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// mkNode builds a node with its size derived from its children, so callers
+// never have to compute it by hand.
+func mkNode[T any](item T, c color, left, right *node[T]) *node[T] {
+	return &node[T]{
+		item:  item,
+		c:     c,
+		size:  1 + nodeSize(left) + nodeSize(right),
+		left:  left,
+		right: right,
+	}
+}
+
 func (n node[T]) copyWithEntry(item T) *node[T] {
 	n.item = item
 	return &n
@@ -43,11 +72,13 @@ func (n node[T]) copyWithEntry(item T) *node[T] {
 
 func (n node[T]) copyWithLeft(left *node[T]) *node[T] {
 	n.left = left
+	n.size = 1 + nodeSize(left) + nodeSize(n.right)
 	return &n
 }
 
 func (n node[T]) copyWithRight(right *node[T]) *node[T] {
 	n.right = right
+	n.size = 1 + nodeSize(n.left) + nodeSize(right)
 	return &n
 }
 
@@ -58,6 +89,7 @@ func (n *node[T]) copyWithColor(c color) *node[T] {
 	return &node[T]{
 		item:  n.item,
 		c:     c,
+		size:  n.size,
 		left:  n.left,
 		right: n.right,
 	}
@@ -67,6 +99,7 @@ func newNode[T any](item T) *node[T] {
 	return &node[T]{
 		item: item,
 		c:    red,
+		size: 1,
 	}
 }
 
@@ -90,13 +123,6 @@ func (n *node[T]) withMaybeNewRight(c Compare[T], inserting *node[T]) *node[T] {
 	return n.right.upsert(c, inserting)
 }
 
-func (n *node[T]) combinedChildren(c Compare[T]) *node[T] {
-	if n.left == nil {
-		return n.right
-	}
-	return n.left.upsert(c, n.right)
-}
-
 func (n *node[T]) upsert(c Compare[T], inserting *node[T]) *node[T] {
 	if inserting == nil {
 		return n
@@ -105,7 +131,7 @@ func (n *node[T]) upsert(c Compare[T], inserting *node[T]) *node[T] {
 	if cmp == 0 {
 		return n.copyWithEntry(inserting.item).upsert(c, inserting.right).balance().upsert(c, inserting.left).balance()
 	}
-	if cmp == -1 {
+	if cmp < 0 {
 		if n.left == nil {
 			return n.copyWithLeft(inserting).balance()
 		}
@@ -117,53 +143,6 @@ func (n *node[T]) upsert(c Compare[T], inserting *node[T]) *node[T] {
 	return n.copyWithRight(n.right.upsert(c, inserting.copyWithLeft(nil).balance())).balance().upsert(c, inserting.left)
 }
 
-func (n *node[T]) intersection(c Compare[T], intersecting *node[T]) *node[T] {
-	if n == nil {
-		return nil
-	}
-	if intersecting == nil {
-		return nil
-	}
-	cmp := c(intersecting.item, n.item)
-	if cmp == 0 {
-		rightIntersection := n.right.intersection(c, intersecting.right).balance()
-		leftIntersection := n.left.intersection(c, intersecting.left).balance()
-		return n.copyWithRight(rightIntersection).balance().copyWithLeft(leftIntersection).balance()
-	}
-	if cmp == -1 {
-		leftIntersect := n.left.intersection(c, intersecting.copyWithRight(nil).balance())
-		if leftIntersect == nil {
-			return n.intersection(c, intersecting.right)
-		}
-		return leftIntersect.copyWithRight(n.intersection(c, intersecting.right)).balance()
-	}
-	rightIntersect := n.right.intersection(c, intersecting.copyWithLeft(nil).balance())
-	if rightIntersect == nil {
-		return n.intersection(c, intersecting.left)
-	}
-	return rightIntersect.copyWithLeft(n.intersection(c, intersecting.left)).balance()
-}
-
-func (n *node[T]) subtract(c Compare[T], subtracting *node[T]) *node[T] {
-	if subtracting == nil {
-		return n
-	}
-	cmp := c(subtracting.item, n.item)
-	if cmp == 0 {
-		return n.combinedChildren(c).subtract(c, subtracting.right).subtract(c, subtracting.left)
-	}
-	if cmp == -1 {
-		if n.left == nil {
-			return n
-		}
-		return n.copyWithLeft(n.left.subtract(c, subtracting.copyWithRight(nil).balance())).balance().subtract(c, subtracting.right)
-	}
-	if n.right == nil {
-		return n
-	}
-	return n.copyWithRight(n.right.subtract(c, subtracting.copyWithLeft(nil).balance())).balance().subtract(c, subtracting.left)
-}
-
 func (n *node[T]) balance() *node[T] {
 	if n == nil {
 		return nil
@@ -176,69 +155,31 @@ func (n *node[T]) balance() *node[T] {
 	// page 3
 	// top case
 	if n.left != nil && n.left.isRed() && n.left.right != nil && n.left.right.isRed() {
-		return &node[T]{
-			item: n.left.right.item,
-			c:    red,
-			left: &node[T]{
-				item:  n.left.item,
-				c:     black,
-				left:  n.left.left,
-				right: n.left.right.left,
-			},
-			right: &node[T]{
-				item:  n.item,
-				c:     black,
-				left:  n.left.right.right,
-				right: n.right,
-			},
-		}
+		return mkNode(n.left.right.item, red,
+			mkNode(n.left.item, black, n.left.left, n.left.right.left),
+			mkNode(n.item, black, n.left.right.right, n.right),
+		)
 	}
 	// left case
 	if n.left != nil && n.left.isRed() && n.left.left != nil && n.left.left.isRed() {
-		return &node[T]{
-			item: n.left.item,
-			c:    red,
-			left: n.left.left.copyWithColor(black),
-			right: &node[T]{
-				item:  n.item,
-				c:     black,
-				left:  n.left.right,
-				right: n.right,
-			},
-		}
+		return mkNode(n.left.item, red,
+			n.left.left.copyWithColor(black),
+			mkNode(n.item, black, n.left.right, n.right),
+		)
 	}
 	// right case
 	if n.right != nil && n.right.isRed() && n.right.right != nil && n.right.right.isRed() {
-		return &node[T]{
-			item: n.right.item,
-			c:    red,
-			left: &node[T]{
-				item:  n.item,
-				c:     black,
-				left:  n.left,
-				right: n.right.left,
-			},
-			right: n.right.right.copyWithColor(black),
-		}
+		return mkNode(n.right.item, red,
+			mkNode(n.item, black, n.left, n.right.left),
+			n.right.right.copyWithColor(black),
+		)
 	}
 	// botttom case
 	if n.right != nil && n.right.isRed() && n.right.left != nil && n.right.left.isRed() {
-		return &node[T]{
-			item: n.right.left.item,
-			c:    red,
-			left: &node[T]{
-				item:  n.item,
-				c:     black,
-				left:  n.left,
-				right: n.right.left.left,
-			},
-			right: &node[T]{
-				item:  n.right.item,
-				c:     black,
-				left:  n.right.left.right,
-				right: n.right.right,
-			},
-		}
+		return mkNode(n.right.left.item, red,
+			mkNode(n.item, black, n.left, n.right.left.left),
+			mkNode(n.right.item, black, n.right.left.right, n.right.right),
+		)
 	}
 	return n
 }
@@ -265,10 +206,7 @@ func NewRedBlack[T any](compareFn Compare[T]) *RedBlackTree[T] {
 func (r *RedBlackTree[T]) Upsert(item T) *RedBlackTree[T] {
 	if r.root == nil {
 		return &RedBlackTree[T]{
-			root: &node[T]{
-				item: item,
-				c:    black,
-			},
+			root:    mkNode(item, black, nil, nil),
 			compare: r.compare,
 		}
 	}
@@ -282,54 +220,70 @@ func (r *RedBlackTree[T]) Delete(item T) *RedBlackTree[T] {
 	if r.root == nil {
 		return r
 	}
+	left, _, right := split(r.root, r.compare, item)
 	return &RedBlackTree[T]{
-		root:    r.root.subtract(r.compare, newNode(item)).copyWithColor(black),
+		root:    blacken(join2(left, right)),
 		compare: r.compare,
 	}
 }
 
 func (r *RedBlackTree[T]) Subtract(other *RedBlackTree[T]) *RedBlackTree[T] {
-	if r.root == nil {
-		return r
-	}
 	return &RedBlackTree[T]{
-		root:    r.root.subtract(r.compare, other.root).copyWithColor(black),
+		root:    blacken(subtract(r.compare, r.root, other.root)),
 		compare: r.compare,
 	}
 }
 
 func (r *RedBlackTree[T]) Union(other *RedBlackTree[T]) *RedBlackTree[T] {
 	// note that union will overwrite shared keys with the values in other
-	if r.root == nil {
-		return other
-	}
 	return &RedBlackTree[T]{
-		root:    r.root.upsert(r.compare, other.root).copyWithColor(black),
+		root:    blacken(union(r.compare, r.root, other.root)),
 		compare: r.compare,
 	}
 }
 
 func (r *RedBlackTree[T]) Intersection(other *RedBlackTree[T]) *RedBlackTree[T] {
-	if r.root == nil {
-		return nil
-	}
 	return &RedBlackTree[T]{
-		root:    r.root.intersection(r.compare, other.root).copyWithColor(black),
+		root:    blacken(intersect(r.compare, r.root, other.root)),
 		compare: r.compare,
 	}
+}
 
+// Split partitions the tree around key: left contains every item less than
+// key, right contains every item greater than key, and found reports
+// whether key itself was present.
+func (r *RedBlackTree[T]) Split(key T) (left *RedBlackTree[T], found bool, right *RedBlackTree[T]) {
+	l, f, ri := split(r.root, r.compare, key)
+	return &RedBlackTree[T]{root: blacken(l), compare: r.compare},
+		f != nil,
+		&RedBlackTree[T]{root: blacken(ri), compare: r.compare}
+}
+
+// Join concatenates left, pivot, and right into a single tree. Every item in
+// left must be less than pivot, and every item in right must be greater than
+// pivot; callers that built left/right via Split satisfy this automatically.
+func Join[T any](left *RedBlackTree[T], pivot T, right *RedBlackTree[T]) *RedBlackTree[T] {
+	c := left.compare
+	if c == nil {
+		c = right.compare
+	}
+	return &RedBlackTree[T]{
+		root:    blacken(join(left.root, pivot, right.root)),
+		compare: c,
+	}
 }
 
 func (r *RedBlackTree[T]) Iterator() Iterator[T] {
 	n := r.root
+	iter := nodeIterator[T]{
+		root:    r.root,
+		compare: r.compare,
+	}
 	if n == nil {
-		empty := nodeIterator[T]{current: nil}
-		return &empty
+		return &iter
 	}
 	var stack []*node[T]
-	iter := nodeIterator[T]{
-		current: n,
-	}
+	iter.current = n
 
 	for n.left != nil {
 		n = n.left
@@ -341,8 +295,12 @@ func (r *RedBlackTree[T]) Iterator() Iterator[T] {
 }
 
 type nodeIterator[T any] struct {
+	root        *node[T]
+	compare     Compare[T]
 	unprocStack []*node[T]
 	current     *node[T]
+	// hi, if non-nil, bounds iteration to items <= *hi (used by RangeIterator).
+	hi *T
 }
 
 func (n *nodeIterator[T]) Elem() T {
@@ -354,18 +312,22 @@ func (n *nodeIterator[T]) Elem() T {
 }
 
 func (n *nodeIterator[T]) HasElem() bool {
-	return n.current != nil
+	if n.current == nil {
+		return false
+	}
+	if n.hi != nil && n.compare(n.current.item, *n.hi) > 0 {
+		return false
+	}
+	return true
 }
 
 func (n *nodeIterator[T]) Next() {
-	if n.current == nil {
+	if !n.HasElem() {
+		n.current = nil
 		return
 	}
 
-	var cursor *node[T]
-	if n.current != nil {
-		cursor = n.current.right
-	}
+	cursor := n.current.right
 	for cursor != nil {
 		n.unprocStack = append(n.unprocStack, cursor)
 		cursor = cursor.left
@@ -377,3 +339,9 @@ func (n *nodeIterator[T]) Next() {
 		n.unprocStack = n.unprocStack[:len(n.unprocStack)-1]
 	}
 }
+
+// Seek repositions the iterator to the first element >= item, descending
+// from the root in O(log n) rather than walking forward element by element.
+func (n *nodeIterator[T]) Seek(item T) {
+	n.current, n.unprocStack = seekStack(n.root, n.compare, item)
+}